@@ -9,6 +9,7 @@ from pathlib import Path
 from typing import Awaitable, Callable, List, Literal, Optional, Tuple, Union
 
 from backend.controllers.skyblockapi import SkyblockAPI
+from backend.exceptions import StoreError
 from models.auction import ActiveAuction, EndedAuction
 
 _here = Path(__file__).parent
@@ -74,15 +75,21 @@ class AuctionHouse:
 
     async def _emit(self, event: str) -> None:
         """
-        Emit an event, calling all of the handlers associated with it.
+        Emit an event, calling all of the handlers associated with it. Handlers
+        which fail to write to the database are logged and counted without
+        interrupting the remaining handlers.
 
         :return: None.
         """
         for handler in self.handlers[event]:
-            if inspect.iscoroutinefunction(handler):
-                await handler(self)
-            else:
-                handler(self)
+            try:
+                if inspect.iscoroutinefunction(handler):
+                    await handler(self)
+                else:
+                    handler(self)
+            except StoreError as e:
+                self.api.record_error(e)
+                logging.error(f'FAIL {e} on {event} ({e.__cause__})')
 
     def on(self,
            event: Literal[
@@ -0,0 +1,172 @@
+import asyncio
+import inspect
+import logging
+from collections import defaultdict
+from configparser import ConfigParser
+from datetime import datetime
+from pathlib import Path
+from typing import (Any, Awaitable, Callable, Dict, List, Literal, Optional,
+                    Union)
+
+from backend.controllers.skyblockapi import SkyblockAPI
+from backend.exceptions import StoreError
+
+_here = Path(__file__).parent
+_cfg = ConfigParser()
+_cfg.read(_here.parent.parent / 'config/spiggy.ini')
+
+# Fall back to defaults for configs copied before these sections existed
+BST_COOLDOWN = _cfg.getfloat('Boosters', 'Cooldown', fallback=300)
+PS_COOLDOWN = _cfg.getfloat('Punishment Stats', 'Cooldown', fallback=300)
+
+
+class Network:
+    """
+    Class which abstracts network-wide queries which are not specific to
+    Skyblock, but which correlate with player counts and market volume.
+
+    :ivar api: The Skyblock API wrapper to use.
+    :ivar boosters: The most recent list of queued and active boosters.
+    :ivar punishment_stats: The most recent Watchdog and staff ban counts.
+    :ivar bst_last_update: The time when boosters was last updated.
+    :ivar ps_last_update: The time when punishment_stats was last updated.
+    """
+    api: SkyblockAPI
+    boosters: List[Dict[str, Any]]
+    punishment_stats: Dict[str, int]
+    handlers: defaultdict[str, List[Union[Callable, Awaitable]]]
+    bst_last_update: Optional[datetime]
+    ps_last_update: Optional[datetime]
+
+    def __init__(self, api: SkyblockAPI) -> None:
+        """
+        Construct a Network instance.
+
+        :param api: The SkyblockAPI instance to use.
+        :return: None.
+        """
+        self.api = api
+        self.boosters = []
+        self.punishment_stats = {}
+        self.handlers = defaultdict(list)
+        self.bst_last_update, self.ps_last_update = None, None
+
+    async def _emit(self, event: str) -> None:
+        """
+        Emit an event, calling all of the handlers associated with it. Handlers
+        which fail to write to the database are logged and counted without
+        interrupting the remaining handlers.
+
+        :return: None.
+        """
+        for handler in self.handlers[event]:
+            try:
+                if inspect.iscoroutinefunction(handler):
+                    await handler(self)
+                else:
+                    handler(self)
+            except StoreError as e:
+                self.api.record_error(e)
+                logging.error(f'FAIL {e} on {event} ({e.__cause__})')
+
+    def on(self,
+           event: Literal['boosters cache', 'punishment stats cache'],
+           handler: Union[Callable, Awaitable]) -> None:
+        """
+        Register a handler to be called on an event.
+
+        :param event: The name of the method to register for.
+        :param handler: The handler to be added.
+        :return: None.
+        """
+        self.handlers[event].append(handler)
+
+    async def cache_boosters(self) -> None:
+        """
+        Cache the network boosters and call the handler functions.
+
+        :return: None.
+        """
+        logging.info('Attempting cache')
+        boosters = await self.api.get_boosters()
+
+        # Update instance variables, the endpoint has no lastUpdated field
+        self.boosters = boosters
+        self.bst_last_update = datetime.now()
+
+        await self._emit('boosters cache')
+
+    async def cache_punishment_stats(self) -> None:
+        """
+        Cache the network punishment stats and call the handler functions.
+
+        :return: None.
+        """
+        logging.info('Attempting cache')
+        punishment_stats = await self.api.get_punishment_stats()
+
+        # Update instance variables, the endpoint has no lastUpdated field
+        self.punishment_stats = punishment_stats
+        self.ps_last_update = datetime.now()
+
+        await self._emit('punishment stats cache')
+
+    async def start_bst_caching(self) -> None:
+        """
+        Start periodically caching boosters.
+
+        :return: None.
+        """
+        while True:
+            await self.cache_boosters()
+            await asyncio.sleep(BST_COOLDOWN)
+
+    async def start_ps_caching(self) -> None:
+        """
+        Start periodically caching punishment stats.
+
+        :return: None.
+        """
+        while True:
+            await self.cache_punishment_stats()
+            await asyncio.sleep(PS_COOLDOWN)
+
+    async def start_caching(self) -> None:
+        """
+        Start periodically caching boosters and punishment stats.
+
+        :return: None.
+        """
+        await asyncio.gather(self.start_bst_caching(), self.start_ps_caching())
+
+
+# Testing
+if __name__ == '__main__':
+    import dotenv
+    import os
+
+    root = Path(__file__).parent.parent.parent
+    dotenv.load_dotenv(dotenv_path=root / 'config/.env')
+    hypixel_key = os.getenv('HYPIXEL_API_KEY')
+
+    logging.basicConfig(level=logging.INFO,
+                        format='[%(asctime)s] %(funcName)s > %(levelname)s: '
+                               '%(message)s',
+                        datefmt='%m/%d/%Y %I:%M:%S %p')
+
+    def print_bst_summary(net: Network) -> None:
+        print(f'Handler got {len(net.boosters)} boosters at '
+              f'{net.bst_last_update}')
+
+    def print_ps_summary(net: Network) -> None:
+        print(f'Handler got punishment stats {net.punishment_stats} at '
+              f'{net.ps_last_update}')
+
+    async def main():
+        async with SkyblockAPI(hypixel_key) as api:
+            net = Network(api)
+            net.on('boosters cache', print_bst_summary)
+            net.on('punishment stats cache', print_ps_summary)
+            await net.start_caching()
+
+    asyncio.run(main())
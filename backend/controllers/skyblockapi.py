@@ -5,7 +5,7 @@ import functools
 import itertools
 import logging
 import math
-from collections import deque
+from collections import Counter, deque
 from configparser import ConfigParser
 from datetime import datetime, timedelta
 from json import JSONDecodeError
@@ -13,9 +13,10 @@ from pathlib import Path
 from typing import Any, Callable, Dict, List, Literal, Optional, Tuple
 
 import requests
-from aiohttp import ClientSession
+from aiohttp import ClientError, ClientSession, ContentTypeError
 
-from backend.exceptions import ResponseCodeError, UnexpectedUpdateError
+from backend.exceptions import (APIUnavailableError, DecodeError,
+                                ResponseCodeError, UnexpectedUpdateError)
 
 
 _here = Path(__file__).parent
@@ -48,6 +49,27 @@ def active_auctions_url(page: int) -> str:
     return f'https://api.hypixel.net/skyblock/auctions?page={page}'
 
 
+def boosters_url(key: str) -> str:
+    """
+    Get the URL for the boosters endpoint with the key parameter filled in.
+
+    :param key: The given key.
+    :return: The corresponding URL.
+    """
+    return f'https://api.hypixel.net/boosters?key={key}'
+
+
+def punishment_stats_url(key: str) -> str:
+    """
+    Get the URL for the punishment stats endpoint with the key parameter
+    filled in.
+
+    :param key: The given key.
+    :return: The corresponding URL.
+    """
+    return f'https://api.hypixel.net/punishmentstats?key={key}'
+
+
 ENDED_AUCTIONS_URL = 'https://api.hypixel.net/skyblock/auctions_ended'
 BAZAAR_URL = 'https://api.hypixel.net/skyblock/bazaar'
 
@@ -84,18 +106,25 @@ class SkyblockAPI:
     form of dictionaries.
 
     The class takes all non-200 responses as invalid, and will make a GET
-    attempt every 30 seconds until a 200 response is received.
+    attempt every 30 seconds until a 200 response is received. Failures are
+    tallied by category in error_counts.
 
     :ivar _session: The session which is used for HTTPS requests.
     :ivar api_key: The API key to use for requests which require it.
     :ivar key_calls: The timestamps of key-authenticated API calls from the
     past minute.
     :ivar limit: The number of key-authenticated API calls allowed per minute.
+    :ivar error_counts: Maps failure categories (APIUnavailable, Decode,
+    UnexpectedUpdate, Store) to the number of times they have occurred.
+    :ivar _validators: Maps URLs fetched conditionally to the request headers
+    which revalidate them and the body last served.
     """
     _session: ClientSession
     api_key: str
     key_calls: deque[datetime]
     limit: int
+    error_counts: Counter[str]
+    _validators: Dict[str, Tuple[Dict[str, str], Dict[str, Any]]]
 
     def __init__(self, api_key: str):
         """
@@ -106,6 +135,8 @@ class SkyblockAPI:
         """
         self.api_key = api_key
         self.key_calls = deque()
+        self.error_counts = Counter()
+        self._validators = {}
         res = requests.get(key_info_url(key=self.api_key))
 
         body = res.json()
@@ -130,6 +161,63 @@ class SkyblockAPI:
         """
         await self._session.close()
 
+    def record_error(self, exc: Exception) -> None:
+        """
+        Tally a failure under the category of its exception.
+
+        :param exc: The exception which was raised.
+        :return: None.
+        """
+        self.error_counts[exc.category] += 1
+
+    async def _get_json(self, url: str,
+                        conditional: bool = False) -> Dict[str, Any]:
+        """
+        GET a URL and decode the response body as JSON.
+
+        If conditional is set and the previous response carried an ETag or
+        Last-Modified header, the request is sent with If-None-Match or
+        If-Modified-Since, and a 304 response serves the previous body without
+        downloading it again.
+
+        :param url: The URL to request.
+        :param conditional: Whether or not to revalidate against the previous
+        response.
+        :return: The decoded response body.
+        :raises ResponseCodeError: If the response code is not 200.
+        :raises APIUnavailableError: If the request could not be completed.
+        :raises DecodeError: If the response body is not valid JSON.
+        """
+        headers, prev_body = self._validators.get(url, ({}, None))
+        try:
+            async with self._session.get(url, headers=headers) as res:
+                if res.status == 304 and prev_body is not None:
+                    logging.debug(f'OK {url} not modified')
+                    return prev_body
+                if res.status != 200:
+                    raise ResponseCodeError(f'Got status {res.status} from '
+                                            f'{url}')
+                body = await res.json()
+                if conditional:
+                    validators = {}
+                    if 'ETag' in res.headers:
+                        validators['If-None-Match'] = res.headers['ETag']
+                    if 'Last-Modified' in res.headers:
+                        validators['If-Modified-Since'] = \
+                            res.headers['Last-Modified']
+                    if validators:
+                        self._validators[url] = validators, body
+                    else:
+                        # Don't revalidate against an outdated body later
+                        self._validators.pop(url, None)
+                        logging.debug(f'{url} sent no ETag or Last-Modified, '
+                                      f'fetching it in full')
+                return body
+        except (ContentTypeError, JSONDecodeError) as e:
+            raise DecodeError(f'Could not decode response from {url}') from e
+        except (ClientError, asyncio.TimeoutError) as e:
+            raise APIUnavailableError(f'Could not reach {url}') from e
+
     async def get_active_auctions(self) \
             -> Tuple[datetime, List[Dict[str, Any]]]:
         """
@@ -144,63 +232,73 @@ class SkyblockAPI:
         # Coroutine to get a single page and raise an exception if something
         # goes wrong
         async def get_page(page: int) -> Dict[str, Any]:
-            async with self._session.get(active_auctions_url(page=page)) as res:
-                if res.status != 200:
-                    raise ResponseCodeError
-                body = await res.json()
-                last_update = datetime.fromtimestamp(body['lastUpdated'] / 1000)
-                if (page0_last_update is not None
-                        and last_update != page0_last_update):
-                    msg = f'Expected ' \
-                          f'{page0_last_update.strftime("%-I:%M:%S %p")} but ' \
-                          f'got {last_update.strftime("%-I:%M:%S %p")} on ' \
-                          f'page {page}'
-                    raise UnexpectedUpdateError(msg)
-                return body
-
-        # Get the page count and the page 0 lastUpdated field
-        try:
-            page0 = await get_page(0)
-            page_count = page0['totalPages']
-            page0_last_update = datetime.fromtimestamp(page0['lastUpdated']
-                                                       / 1000)
-        except (ResponseCodeError, UnexpectedUpdateError):
-            logging.exception('FAIL Could not get page 0, will try '
-                              'again in 30 seconds')
-            await asyncio.sleep(30)
-            return await self.get_active_auctions()
-
-        # Wait until ideal time
-        now_time = datetime.now()
-        ideal_time = page0_last_update + timedelta(seconds=AA_IDEAL_DELAY)
-
-        # If ideal time is already passed, try to get the next snapshot
-        if now_time > ideal_time:
-            diff_minutes = (now_time - ideal_time).total_seconds() / 60
-            delta = timedelta(minutes=math.ceil(diff_minutes))
-            page0_last_update += delta
-            ideal_time += delta
-        logging.info(f'Waiting until next ideal time '
-                     f'{ideal_time.strftime("%-I:%M:%S %p")} to capture '
-                     f'snapshot with timestamp '
-                     f'{page0_last_update.strftime("%-I:%M:%S %p")}')
-        await asyncio.sleep((ideal_time - now_time).total_seconds())
-
-        # Get a snapshot
-        try:
-            tasks = (get_page(p) for p in range(page_count))
-            bodies = await asyncio.gather(*tasks)
-            auctions = list(itertools.chain.from_iterable(
-                body['auctions'] for body in bodies
-            ))
-            logging.debug(f'OK got active auctions snapshot with timestamp '
-                          f'{page0_last_update.strftime("%-I:%M:%S %p")}')
-            return page0_last_update, auctions
-        except (ResponseCodeError, UnexpectedUpdateError):
-            logging.exception('FAIL Could not get snapshot, will try '
-                              'for new snapshot in 30 seconds')
-            await asyncio.sleep(30)
-            return await self.get_active_auctions()
+            body = await self._get_json(active_auctions_url(page=page))
+            try:
+                last_update = datetime.fromtimestamp(body['lastUpdated']
+                                                     / 1000)
+            except (KeyError, TypeError) as e:
+                raise DecodeError(f'Unexpected body on page {page}') from e
+            if 'totalPages' not in body or 'auctions' not in body:
+                raise DecodeError(f'Unexpected body on page {page}')
+            if (page0_last_update is not None
+                    and last_update != page0_last_update):
+                msg = f'Expected ' \
+                      f'{page0_last_update.strftime("%-I:%M:%S %p")} but ' \
+                      f'got {last_update.strftime("%-I:%M:%S %p")} on ' \
+                      f'page {page}'
+                raise UnexpectedUpdateError(msg)
+            return body
+
+        while True:
+            # Get the page count and the page 0 lastUpdated field
+            page0_last_update = None
+            try:
+                page0 = await get_page(0)
+                page_count = page0['totalPages']
+                page0_last_update = datetime.fromtimestamp(
+                    page0['lastUpdated'] / 1000
+                )
+            except (APIUnavailableError, DecodeError,
+                    UnexpectedUpdateError) as e:
+                self.record_error(e)
+                logging.warning(f'FAIL Could not get page 0 ({e}), will try '
+                                f'again in 30 seconds')
+                await asyncio.sleep(30)
+                continue
+
+            # Wait until ideal time
+            now_time = datetime.now()
+            ideal_time = page0_last_update + timedelta(seconds=AA_IDEAL_DELAY)
+
+            # If ideal time is already passed, try to get the next snapshot
+            if now_time > ideal_time:
+                diff_minutes = (now_time - ideal_time).total_seconds() / 60
+                delta = timedelta(minutes=math.ceil(diff_minutes))
+                page0_last_update += delta
+                ideal_time += delta
+            logging.info(f'Waiting until next ideal time '
+                         f'{ideal_time.strftime("%-I:%M:%S %p")} to capture '
+                         f'snapshot with timestamp '
+                         f'{page0_last_update.strftime("%-I:%M:%S %p")}')
+            await asyncio.sleep((ideal_time - now_time).total_seconds())
+
+            # Get a snapshot
+            try:
+                tasks = (get_page(p) for p in range(page_count))
+                bodies = await asyncio.gather(*tasks)
+                auctions = list(itertools.chain.from_iterable(
+                    body['auctions'] for body in bodies
+                ))
+                logging.debug(f'OK got active auctions snapshot with '
+                              f'timestamp '
+                              f'{page0_last_update.strftime("%-I:%M:%S %p")}')
+                return page0_last_update, auctions
+            except (APIUnavailableError, DecodeError,
+                    UnexpectedUpdateError) as e:
+                self.record_error(e)
+                logging.warning(f'FAIL Could not get snapshot ({e}), will try '
+                                f'for new snapshot in 30 seconds')
+                await asyncio.sleep(30)
 
     async def get_ended_auctions(self) -> Tuple[datetime, List[Dict[str, Any]]]:
         """
@@ -210,18 +308,25 @@ class SkyblockAPI:
         auctions.
         """
         logging.debug('Attempting to get ended auctions')
-        async with self._session.get(ENDED_AUCTIONS_URL) as res:
-            if res.status != 200:
-                logging.debug('FAIL could not get ended auctions, will try '
-                              'again in 30 seconds')
+        while True:
+            try:
+                body = await self._get_json(ENDED_AUCTIONS_URL,
+                                            conditional=True)
+                try:
+                    last_update = datetime.fromtimestamp(body['lastUpdated']
+                                                         / 1000)
+                    auctions = body['auctions']
+                except (KeyError, TypeError) as e:
+                    raise DecodeError('Unexpected ended auctions body') from e
+                break
+            except (APIUnavailableError, DecodeError) as e:
+                self.record_error(e)
+                logging.warning(f'FAIL could not get ended auctions ({e}), '
+                                f'will try again in 30 seconds')
                 await asyncio.sleep(30)
-                return await self.get_active_auctions()
-            body = await res.json()
-            last_update = datetime.fromtimestamp(body['lastUpdated'] / 1000)
-            auctions = body['auctions']
-            logging.debug(f'OK got ended auctions with timestamp '
-                          f'{last_update.strftime("%-I:%M:%S %p")}')
-            return last_update, auctions
+        logging.debug(f'OK got ended auctions with timestamp '
+                      f'{last_update.strftime("%-I:%M:%S %p")}')
+        return last_update, auctions
 
     async def get_bazaar_products(self) -> Tuple[datetime, Dict[str, Any]]:
         """
@@ -231,18 +336,89 @@ class SkyblockAPI:
         products.
         """
         logging.debug('Attempting to get bazaar products')
-        async with self._session.get(BAZAAR_URL) as res:
-            if res.status != 200:
-                logging.debug('FAIL could not get bazaar products, will try '
-                              'again in 30 seconds')
+        while True:
+            try:
+                body = await self._get_json(BAZAAR_URL, conditional=True)
+                try:
+                    last_update = datetime.fromtimestamp(body['lastUpdated']
+                                                         / 1000)
+                    products = body['products']
+                except (KeyError, TypeError) as e:
+                    raise DecodeError('Unexpected bazaar body') from e
+                break
+            except (APIUnavailableError, DecodeError) as e:
+                self.record_error(e)
+                logging.warning(f'FAIL could not get bazaar products ({e}), '
+                                f'will try again in 30 seconds')
+                await asyncio.sleep(30)
+        logging.debug(f'OK got bazaar products with timestamp '
+                      f'{last_update.strftime("%-I:%M:%S %p")}')
+        return last_update, products
+
+    @use_key
+    async def _get_keyed_json(self, url: str) -> Dict[str, Any]:
+        """
+        GET a key-authenticated URL and decode the response body as JSON,
+        counting the request against the key's rate limit.
+
+        :param url: The URL to request.
+        :return: The decoded response body.
+        """
+        return await self._get_json(url)
+
+    async def get_boosters(self) -> List[Dict[str, Any]]:
+        """
+        Get the currently queued and active network boosters.
+
+        :return: The list of boosters.
+        """
+        logging.debug('Attempting to get boosters')
+        while True:
+            try:
+                body = await self._get_keyed_json(
+                    boosters_url(key=self.api_key)
+                )
+                try:
+                    boosters = body['boosters']
+                except (KeyError, TypeError) as e:
+                    raise DecodeError('Unexpected boosters body') from e
+                break
+            except (APIUnavailableError, DecodeError) as e:
+                self.record_error(e)
+                logging.warning(f'FAIL could not get boosters ({e}), will try '
+                                f'again in 30 seconds')
+                await asyncio.sleep(30)
+        logging.debug(f'OK got {len(boosters)} boosters')
+        return boosters
+
+    async def get_punishment_stats(self) -> Dict[str, int]:
+        """
+        Get the network-wide Watchdog and staff ban counts.
+
+        :return: Dict containing the watchdog_lastMinute,
+        watchdog_rollingDaily and staff_rollingDaily ban counts.
+        """
+        logging.debug('Attempting to get punishment stats')
+        keys = ('watchdog_lastMinute', 'watchdog_rollingDaily',
+                'staff_rollingDaily')
+        while True:
+            try:
+                body = await self._get_keyed_json(
+                    punishment_stats_url(key=self.api_key)
+                )
+                try:
+                    stats = {k: body[k] for k in keys}
+                except (KeyError, TypeError) as e:
+                    raise DecodeError('Unexpected punishment stats body') \
+                        from e
+                break
+            except (APIUnavailableError, DecodeError) as e:
+                self.record_error(e)
+                logging.warning(f'FAIL could not get punishment stats ({e}), '
+                                f'will try again in 30 seconds')
                 await asyncio.sleep(30)
-                return await self.get_bazaar_products()
-            body = await res.json()
-            last_update = datetime.fromtimestamp(body['lastUpdated'] / 1000)
-            products = body['products']
-            logging.debug(f'OK got bazaar products with timestamp '
-                          f'{last_update.strftime("%-I:%M:%S %p")}')
-            return last_update, products
+        logging.debug('OK got punishment stats')
+        return stats
 
 
 # Something to test the API wrapper with
@@ -277,6 +453,13 @@ if __name__ == '__main__':
                     t, products = await api.get_bazaar_products()
                     print(f'OK got {len(products)} products at {t}')
                     print(str(products)[:100] + '...')
+                elif inp[0] == 'boosters':
+                    boosters = await api.get_boosters()
+                    print(f'OK got {len(boosters)} boosters')
+                    print(str(boosters)[:100] + '...')
+                elif inp[0] == 'punishments':
+                    stats = await api.get_punishment_stats()
+                    print(f'OK got punishment stats {stats}')
                 await asyncio.sleep(3)
 
     asyncio.run(main())
@@ -2,6 +2,7 @@ import functools
 import logging
 import sqlite3
 import statistics
+from collections import Counter
 from configparser import ConfigParser
 from datetime import datetime, timedelta
 from pathlib import Path
@@ -11,6 +12,8 @@ from fuzzywuzzy import process
 
 from backend import constants
 from backend.controllers.auctionhouse import AuctionHouse
+from backend.controllers.network import Network
+from backend.exceptions import StoreError
 from models.auction import ActiveAuction
 from models.dashboard import Dashboard
 
@@ -29,17 +32,23 @@ WRITE_TO_DATABASE = _cfg['Database'].getboolean('WriteToDatabase')
 def db_write(func: Callable) -> Callable:
     """
     Wrapper which ensures that the config allows writing to the database before
-    writing to it, and commits after the operation.
+    writing to it, and commits after the operation. If the operation fails, it
+    is rolled back and a StoreError is raised.
 
-    :param func:
-    :return:
+    :param func: The database operation to be wrapped.
+    :return: The wrapped operation.
     """
     @functools.wraps(func)
     def wrapper(*args, **kwargs):
         if WRITE_TO_DATABASE:
-            func(*args, **kwargs)
+            try:
+                func(*args, **kwargs)
+                _conn.commit()
+            except sqlite3.Error as e:
+                _conn.rollback()
+                raise StoreError(f'Could not write to database in '
+                                 f'{func.__name__}') from e
             logging.info('OK wrote to database')
-            _conn.commit()
     return wrapper
 
 
@@ -155,6 +164,57 @@ _conn.execute(
     'ON bazaar_history(item_id)'
 )
 
+# Table which tracks the number of queued and active boosters per game type
+_conn.execute(
+    'CREATE TABLE IF NOT EXISTS booster_history ('
+    '  timestamp      DATETIME DEFAULT CURRENT_TIMESTAMP,'
+    '  game_type      INTEGER,'
+    '  booster_ct     INTEGER'
+    ')'
+)
+
+
+@db_write
+def save_booster_history(net: Network) -> None:
+    """
+    Record the booster counts of the given Network instance into the database.
+
+    :param net: The Network instance to use.
+    :return: None.
+    """
+    sql = 'INSERT INTO booster_history VALUES (?, ?, ?)'
+    counts = Counter(booster['gameType'] for booster in net.boosters)
+    for game_type, count in counts.items():
+        _conn.execute(sql, (net.bst_last_update, game_type, count))
+
+
+# Table which tracks network-wide ban counts
+_conn.execute(
+    'CREATE TABLE IF NOT EXISTS punishment_history ('
+    '  timestamp              DATETIME DEFAULT CURRENT_TIMESTAMP,'
+    '  watchdog_last_minute   INTEGER,'
+    '  watchdog_rolling_daily INTEGER,'
+    '  staff_rolling_daily    INTEGER'
+    ')'
+)
+
+
+@db_write
+def save_punishment_history(net: Network) -> None:
+    """
+    Record the punishment stats of the given Network instance into the
+    database.
+
+    :param net: The Network instance to use.
+    :return: None.
+    """
+    sql = 'INSERT INTO punishment_history VALUES (?, ?, ?, ?)'
+    stats = net.punishment_stats
+    _conn.execute(sql, (net.ps_last_update, stats['watchdog_lastMinute'],
+                        stats['watchdog_rollingDaily'],
+                        stats['staff_rollingDaily']))
+
+
 # Table which maps item IDs to base names and occurrences in different rarities
 _conn.execute(
     'CREATE TABLE IF NOT EXISTS item_info ('
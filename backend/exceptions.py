@@ -1,12 +1,43 @@
-class ResponseCodeError(Exception):
+class APIUnavailableError(Exception):
+    """
+    Called when the Skyblock API cannot be reached or does not serve a usable
+    response.
+
+    :cvar category: The failure category used for error counts.
+    """
+    category = 'APIUnavailable'
+
+
+class ResponseCodeError(APIUnavailableError):
     """
     Called when the Skyblock API returns an unexpected response code.
     """
     pass
 
 
+class DecodeError(Exception):
+    """
+    Called when a Skyblock API response body cannot be decoded into the
+    expected shape.
+
+    :cvar category: The failure category used for error counts.
+    """
+    category = 'Decode'
+
+
 class UnexpectedUpdateError(Exception):
     """
     Called when the Skyblock API updates during a cache.
+
+    :cvar category: The failure category used for error counts.
     """
-    pass
+    category = 'UnexpectedUpdate'
+
+
+class StoreError(Exception):
+    """
+    Called when a write to the database fails.
+
+    :cvar category: The failure category used for error counts.
+    """
+    category = 'Store'
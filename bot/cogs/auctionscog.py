@@ -1,3 +1,4 @@
+import logging
 from configparser import ConfigParser
 from datetime import timedelta
 from pathlib import Path
@@ -11,6 +12,7 @@ from discord_slash.utils.manage_commands import create_choice, create_option
 from backend import constants
 from backend.controllers.auctionhouse import AuctionHouse
 from backend.database import database
+from backend.exceptions import StoreError
 from bot import embeds, utils
 from bot.utils import cog_slash
 from models.dashboard import Dashboard
@@ -144,7 +146,15 @@ class AuctionsCog(Cog):
                               message_id=message.id, channel_id=ctx.channel_id,
                               title=title, description=description)
         await message.edit(embed=dashboard.get_embed())
-        database.save_dashboard(dashboard)
+        try:
+            database.save_dashboard(dashboard)
+        except StoreError as e:
+            # The dashboard would never be refreshed, so don't leave it up
+            self.ah.api.record_error(e)
+            logging.error(f'FAIL {e} ({e.__cause__})')
+            await message.delete()
+            await ctx.send('The dashboard could not be saved, try again '
+                           'later.', hidden=True)
 
     async def refresh_dashboards(self, *_) -> None:
         """
@@ -159,4 +169,8 @@ class AuctionsCog(Cog):
                 message = await channel.fetch_message(dashboard.message_id)
                 await message.edit(embed=dashboard.get_embed())
             except (AttributeError, NotFound, Forbidden):
-                database.delete_dashboard(message_id=dashboard.message_id)
+                try:
+                    database.delete_dashboard(message_id=dashboard.message_id)
+                except StoreError as e:
+                    self.ah.api.record_error(e)
+                    logging.error(f'FAIL {e} ({e.__cause__})')
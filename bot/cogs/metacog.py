@@ -4,6 +4,7 @@ from discord import TextChannel
 from discord.ext.commands import Bot, Cog
 from discord_slash import SlashContext
 
+from backend.controllers.skyblockapi import SkyblockAPI
 from bot import utils
 from bot.utils import cog_slash
 
@@ -11,12 +12,17 @@ from bot.utils import cog_slash
 class MetaCog(Cog):
     """
     Bot cog which handles meta commands.
+
+    :ivar bot: The bot instance which holds this cog.
+    :ivar api: The Skyblock API wrapper whose error counts are reported.
     """
     bot: Bot
+    api: SkyblockAPI
     dump_channel: Optional[TextChannel]
 
-    def __init__(self, bot: Bot):
+    def __init__(self, bot: Bot, api: SkyblockAPI):
         self.bot = bot
+        self.api = api
         self.dump_channel = None
 
     @cog_slash(
@@ -24,4 +30,6 @@ class MetaCog(Cog):
         description='Ping the bot'
     )
     async def ping(self, ctx: SlashContext):
-        await ctx.send('Pong!')
+        counts = ', '.join(f'{category}: {count}' for category, count
+                           in sorted(self.api.error_counts.items()))
+        await ctx.send(f'Pong! Errors since startup: {counts or "none"}')
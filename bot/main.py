@@ -11,6 +11,7 @@ from discord_slash import SlashCommand
 
 from backend.controllers.auctionhouse import AuctionHouse
 from backend.controllers.bazaar import Bazaar
+from backend.controllers.network import Network
 from backend.controllers.skyblockapi import SkyblockAPI
 from backend.database import database
 from bot.cogs.auctionscog import AuctionsCog
@@ -44,9 +45,10 @@ async def main() -> None:
     async with SkyblockAPI(hypixel_key) as api:
         ah = AuctionHouse(api)
         bz = Bazaar(api)
+        net = Network(api)
 
         # Load cogs
-        bot.add_cog(MetaCog(bot=bot))
+        bot.add_cog(MetaCog(bot=bot, api=api))
         bot.add_cog(AuctionsCog(bot=bot, ah=ah))
 
         # On every active auctions cache, update item ID to base name
@@ -61,9 +63,14 @@ async def main() -> None:
         # When the sale buffer is ready, save it tot he database
         ah.on('sale buffer ready', database.save_avg_sale_history)
 
+        # On every network cache, save the booster counts and ban counts
+        net.on('boosters cache', database.save_booster_history)
+        net.on('punishment stats cache', database.save_punishment_history)
+
         # Start all processes
         await asyncio.gather(ah.start_caching(),
                              bz.start_caching(),
+                             net.start_caching(),
                              bot.start(spiggy_token))
 
 if __name__ == '__main__':
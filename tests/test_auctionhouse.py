@@ -0,0 +1,45 @@
+import unittest
+from configparser import ConfigParser
+from pathlib import Path
+from unittest import mock
+
+from backend.exceptions import StoreError
+
+# The modules read config/spiggy.ini on import, use the template instead
+_template = Path(__file__).parent.parent / 'config/spiggy-template.ini'
+_read = ConfigParser.read
+with mock.patch.object(ConfigParser, 'read',
+                       lambda self, _: _read(self, _template)):
+    from backend.controllers import skyblockapi
+    from backend.controllers.auctionhouse import AuctionHouse
+
+
+def make_api() -> skyblockapi.SkyblockAPI:
+    key_info = mock.Mock()
+    key_info.json.return_value = {'success': True, 'record': {'limit': 120}}
+    with mock.patch.object(skyblockapi.requests, 'get',
+                           return_value=key_info):
+        return skyblockapi.SkyblockAPI('key')
+
+
+class TestEmit(unittest.IsolatedAsyncioTestCase):
+
+    async def test_store_error_is_counted_and_skipped(self):
+        ah = AuctionHouse(make_api())
+        called = []
+
+        def failing_handler(_):
+            raise StoreError('Could not write to database')
+
+        async def next_handler(_):
+            called.append(True)
+
+        ah.on('lbin buffer ready', failing_handler)
+        ah.on('lbin buffer ready', next_handler)
+        await ah._emit('lbin buffer ready')
+        self.assertEqual(ah.api.error_counts['Store'], 1)
+        self.assertEqual(called, [True])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,72 @@
+import sqlite3
+import sys
+import unittest
+from configparser import ConfigParser
+from datetime import datetime
+from pathlib import Path
+from unittest import mock
+
+from backend.exceptions import StoreError
+
+# The module reads config/spiggy.ini and opens the database file on import,
+# use the template and an in-memory database instead. The dashboard model is
+# only needed for type hints and pulls in the bot's dependencies.
+_template = Path(__file__).parent.parent / 'config/spiggy-template.ini'
+_read = ConfigParser.read
+_connect = sqlite3.connect
+with mock.patch.object(ConfigParser, 'read',
+                       lambda self, _: _read(self, _template)), \
+        mock.patch.object(sqlite3, 'connect',
+                          lambda _, **kwargs: _connect(':memory:', **kwargs)):
+    import backend.controllers.auctionhouse  # noqa: F401
+    import backend.controllers.network  # noqa: F401
+    with mock.patch.dict(sys.modules, {'models.dashboard': mock.Mock()}):
+        from backend.database import database
+
+
+class TestDbWrite(unittest.TestCase):
+
+    def test_failure_raises_store_error(self):
+        @database.db_write
+        def write():
+            database._conn.execute('INSERT INTO missing_table VALUES (1)')
+
+        with self.assertRaises(StoreError) as cm:
+            write()
+        self.assertIsInstance(cm.exception.__cause__, sqlite3.Error)
+
+    def test_failure_rolls_back(self):
+        @database.db_write
+        def write():
+            database._conn.execute(
+                'INSERT INTO lbin_history VALUES (?, ?, ?, ?)',
+                (datetime.now(), 'ROLLED_BACK', 'COMMON', 1.0)
+            )
+            database._conn.execute('INSERT INTO missing_table VALUES (1)')
+
+        with self.assertRaises(StoreError):
+            write()
+        count = database._conn.execute(
+            'SELECT COUNT(*) FROM lbin_history WHERE item_id = ?',
+            ('ROLLED_BACK',)
+        ).fetchone()[0]
+        self.assertEqual(count, 0)
+
+
+class TestBoosterHistory(unittest.TestCase):
+
+    def test_counts_boosters_per_game_type(self):
+        now = datetime.now()
+        net = mock.Mock(boosters=[{'gameType': 24}, {'gameType': 24},
+                                  {'gameType': 58}],
+                        bst_last_update=now)
+        database.save_booster_history(net)
+        rows = database._conn.execute(
+            'SELECT game_type, booster_ct FROM booster_history '
+            'WHERE timestamp = ? ORDER BY game_type', (now,)
+        ).fetchall()
+        self.assertEqual(rows, [(24, 2), (58, 1)])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,197 @@
+import asyncio
+import unittest
+from configparser import ConfigParser
+from pathlib import Path
+from typing import Any, Dict, List, Optional, Union
+from unittest import mock
+
+from aiohttp import ContentTypeError
+
+from backend.exceptions import (APIUnavailableError, DecodeError,
+                                ResponseCodeError)
+
+# The module reads config/spiggy.ini on import, use the template instead
+_template = Path(__file__).parent.parent / 'config/spiggy-template.ini'
+_read = ConfigParser.read
+with mock.patch.object(ConfigParser, 'read',
+                       lambda self, _: _read(self, _template)):
+    from backend.controllers import skyblockapi
+
+NOW_MS = 1622505600000
+
+
+class FakeResponse:
+    """
+    Stand-in for an aiohttp response which serves a fixed status and body.
+    """
+    def __init__(self, status: int, body: Any = None,
+                 bad_content_type: bool = False,
+                 headers: Optional[Dict[str, str]] = None) -> None:
+        self.status = status
+        self.body = body
+        self.bad_content_type = bad_content_type
+        self.headers = headers or {}
+
+    async def __aenter__(self) -> 'FakeResponse':
+        return self
+
+    async def __aexit__(self, *args) -> None:
+        pass
+
+    async def json(self) -> Any:
+        if self.bad_content_type:
+            raise ContentTypeError(mock.Mock(), ())
+        return self.body
+
+
+class FakeSession:
+    """
+    Stand-in for an aiohttp session which serves queued responses (or raises
+    queued exceptions) in order and records the requested URLs and headers.
+    """
+    def __init__(self, *responses: Union[FakeResponse, Exception]) -> None:
+        self.responses = list(responses)
+        self.urls: List[str] = []
+        self.headers: List[Dict[str, str]] = []
+
+    def get(self, url: str,
+            headers: Optional[Dict[str, str]] = None) -> FakeResponse:
+        self.urls.append(url)
+        self.headers.append(headers or {})
+        res = self.responses.pop(0)
+        if isinstance(res, Exception):
+            raise res
+        return res
+
+
+def make_api(session: FakeSession) -> skyblockapi.SkyblockAPI:
+    key_info = mock.Mock()
+    key_info.json.return_value = {'success': True, 'record': {'limit': 120}}
+    with mock.patch.object(skyblockapi.requests, 'get',
+                           return_value=key_info):
+        api = skyblockapi.SkyblockAPI('key')
+    api._session = session
+    return api
+
+
+@mock.patch.object(skyblockapi.asyncio, 'sleep', mock.AsyncMock())
+class TestSkyblockAPI(unittest.IsolatedAsyncioTestCase):
+
+    async def test_non_200_raises_response_code_error(self):
+        api = make_api(FakeSession(FakeResponse(503)))
+        with self.assertRaises(ResponseCodeError) as cm:
+            await api._get_json(skyblockapi.BAZAAR_URL)
+        self.assertIsInstance(cm.exception, APIUnavailableError)
+
+    async def test_timeout_raises_api_unavailable_error(self):
+        api = make_api(FakeSession(asyncio.TimeoutError()))
+        with self.assertRaises(APIUnavailableError):
+            await api._get_json(skyblockapi.BAZAAR_URL)
+
+    async def test_bad_content_type_raises_decode_error(self):
+        api = make_api(FakeSession(FakeResponse(200, bad_content_type=True)))
+        with self.assertRaises(DecodeError):
+            await api._get_json(skyblockapi.BAZAAR_URL)
+
+    async def test_ended_auctions_retries_itself(self):
+        body = {'lastUpdated': NOW_MS, 'auctions': [{'uuid': 'a'}]}
+        session = FakeSession(FakeResponse(503), FakeResponse(200, body))
+        api = make_api(session)
+        _, auctions = await api.get_ended_auctions()
+        self.assertEqual(auctions, [{'uuid': 'a'}])
+        self.assertEqual(session.urls, [skyblockapi.ENDED_AUCTIONS_URL] * 2)
+        self.assertEqual(api.error_counts['APIUnavailable'], 1)
+
+    async def test_long_outage_does_not_recurse(self):
+        body = {'lastUpdated': NOW_MS, 'products': {}}
+        outage = [FakeResponse(503) for _ in range(2000)]
+        api = make_api(FakeSession(*outage, FakeResponse(200, body)))
+        await api.get_bazaar_products()
+        self.assertEqual(api.error_counts['APIUnavailable'], 2000)
+
+    async def test_missing_key_counts_decode_error(self):
+        body = {'lastUpdated': NOW_MS, 'products': {'ENCHANTED_COAL': {}}}
+        session = FakeSession(FakeResponse(200, {'lastUpdated': NOW_MS}),
+                              FakeResponse(200, body))
+        api = make_api(session)
+        _, products = await api.get_bazaar_products()
+        self.assertEqual(products, {'ENCHANTED_COAL': {}})
+        self.assertEqual(api.error_counts['Decode'], 1)
+
+    async def test_null_body_counts_decode_error(self):
+        body = {'lastUpdated': NOW_MS, 'auctions': []}
+        session = FakeSession(FakeResponse(200, None), FakeResponse(200, body))
+        api = make_api(session)
+        await api.get_ended_auctions()
+        self.assertEqual(api.error_counts, {'Decode': 1})
+
+    async def test_boosters_retries_with_key(self):
+        body = {'success': True, 'boosters': [{'gameType': 24}]}
+        session = FakeSession(FakeResponse(403), FakeResponse(200, body))
+        api = make_api(session)
+        boosters = await api.get_boosters()
+        self.assertEqual(boosters, [{'gameType': 24}])
+        self.assertEqual(session.urls, [skyblockapi.boosters_url('key')] * 2)
+        self.assertEqual(api.error_counts['APIUnavailable'], 1)
+
+    async def test_punishment_stats_missing_key_is_retried(self):
+        body = {'success': True, 'watchdog_lastMinute': 2,
+                'watchdog_rollingDaily': 3000, 'staff_rollingDaily': 900}
+        session = FakeSession(FakeResponse(200, {'success': True}),
+                              FakeResponse(200, body))
+        api = make_api(session)
+        stats = await api.get_punishment_stats()
+        self.assertEqual(stats, {'watchdog_lastMinute': 2,
+                                 'watchdog_rollingDaily': 3000,
+                                 'staff_rollingDaily': 900})
+        self.assertEqual(api.error_counts['Decode'], 1)
+
+    async def test_not_modified_serves_previous_body(self):
+        body = {'lastUpdated': NOW_MS, 'products': {}}
+        session = FakeSession(FakeResponse(200, body, headers={'ETag': '"a"'}),
+                              FakeResponse(304))
+        api = make_api(session)
+        first = await api.get_bazaar_products()
+        second = await api.get_bazaar_products()
+        self.assertEqual(first, second)
+        self.assertEqual(session.headers, [{}, {'If-None-Match': '"a"'}])
+
+    async def test_last_modified_sends_if_modified_since(self):
+        body = {'lastUpdated': NOW_MS, 'auctions': []}
+        date = 'Tue, 01 Jun 2021 00:00:00 GMT'
+        session = FakeSession(
+            FakeResponse(200, body, headers={'Last-Modified': date}),
+            FakeResponse(304)
+        )
+        api = make_api(session)
+        first = await api.get_ended_auctions()
+        second = await api.get_ended_auctions()
+        self.assertEqual(first, second)
+        self.assertEqual(session.headers, [{}, {'If-Modified-Since': date}])
+
+    async def test_response_without_validators_drops_stored_entry(self):
+        old = {'lastUpdated': NOW_MS, 'products': {}}
+        new = {'lastUpdated': NOW_MS + 20000, 'products': {}}
+        session = FakeSession(FakeResponse(200, old, headers={'ETag': '"a"'}),
+                              FakeResponse(200, new),
+                              FakeResponse(200, new))
+        api = make_api(session)
+        await api.get_bazaar_products()
+        await api.get_bazaar_products()
+        self.assertNotIn(skyblockapi.BAZAAR_URL, api._validators)
+        await api.get_bazaar_products()
+        self.assertEqual(session.headers,
+                         [{}, {'If-None-Match': '"a"'}, {}])
+
+    async def test_unconditional_fetch_sends_no_validators(self):
+        body = {'lastUpdated': NOW_MS, 'products': {}}
+        session = FakeSession(FakeResponse(200, body, headers={'ETag': '"a"'}),
+                              FakeResponse(200, body))
+        api = make_api(session)
+        await api._get_json(skyblockapi.BAZAAR_URL)
+        await api._get_json(skyblockapi.BAZAAR_URL)
+        self.assertEqual(session.headers, [{}, {}])
+
+
+if __name__ == '__main__':
+    unittest.main()